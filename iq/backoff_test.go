@@ -0,0 +1,141 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package iq
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// withinJitter asserts got is within frac (e.g. 0.2 for +/-20%) of want.
+func withinJitter(t *testing.T, got, want time.Duration, frac float64) {
+	t.Helper()
+	delta := time.Duration(float64(want) * frac)
+	if got < want-delta || got > want+delta {
+		t.Errorf("got %s, want within %s of %s", got, delta, want)
+	}
+}
+
+func TestBackoffNextSleep(t *testing.T) {
+	b := Backoff{
+		InitialInterval: 100 * time.Millisecond,
+		MaxInterval:     time.Second,
+		Multiplier:      2,
+		Jitter:          0.01,
+	}
+
+	// attempt 0 is the wait before the first retry, so it should be ~InitialInterval
+	withinJitter(t, b.nextSleep(0), 100*time.Millisecond, 0.01)
+	// each subsequent attempt grows by Multiplier
+	withinJitter(t, b.nextSleep(1), 200*time.Millisecond, 0.01)
+	withinJitter(t, b.nextSleep(2), 400*time.Millisecond, 0.01)
+	// growth stops once MaxInterval is reached
+	withinJitter(t, b.nextSleep(10), time.Second, 0.01)
+}
+
+// TestAuditBackoffOnPollAttemptSequence asserts that the first call to OnPollAttempt observes
+// attempt 0 (the documented wait before the first retry), with each subsequent call observing
+// the next attempt number in sequence.
+func TestAuditBackoffOnPollAttemptSequence(t *testing.T) {
+	var mu sync.Mutex
+	var pollCount int
+	const donePoll = 3 // succeed on the 4th poll (attempts 0, 1, 2 observed, then done)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/applications", func(w http.ResponseWriter, r *http.Request) {
+		publicID := r.URL.Query().Get("publicId")
+		_ = json.NewEncoder(w).Encode(applicationResponse{
+			Applications: []application{{ID: "internal-" + publicID}},
+		})
+	})
+	mux.HandleFunc("/api/v2/scan/applications/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		_ = json.NewEncoder(w).Encode(thirdPartyAPIResult{
+			StatusURL: "api/v2/polling/report-id",
+		})
+	})
+	mux.HandleFunc("/api/v2/polling/report-id", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		count := pollCount
+		pollCount++
+		mu.Unlock()
+
+		if count < donePoll {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(StatusURLResult{
+			PolicyAction:  PolicyActionNone,
+			ReportHTMLURL: "ui/report/report-id",
+		})
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	logger := logrus.New()
+	logger.Out = io.Discard
+
+	var attemptsMu sync.Mutex
+	var attempts []int
+
+	server, err := New(logger, Options{
+		User:        "user",
+		Token:       "token",
+		Application: "test-app",
+		Server:      ts.URL,
+		Stage:       "build",
+		MaxRetries:  10,
+		Backoff: Backoff{
+			InitialInterval: time.Millisecond,
+			MaxInterval:     10 * time.Millisecond,
+			Multiplier:      2,
+			Jitter:          0.01,
+		},
+		OnPollAttempt: func(attempt int, nextSleep time.Duration) {
+			attemptsMu.Lock()
+			attempts = append(attempts, attempt)
+			attemptsMu.Unlock()
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error constructing server: %s", err)
+	}
+
+	if _, err := server.AuditWithSbom("<sbom/>"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	attemptsMu.Lock()
+	defer attemptsMu.Unlock()
+	want := []int{0, 1, 2}
+	if len(attempts) != len(want) {
+		t.Fatalf("got attempts %v, want %v", attempts, want)
+	}
+	for n, attempt := range attempts {
+		if attempt != want[n] {
+			t.Errorf("attempts[%d] = %d, want %d", n, attempt, want[n])
+		}
+	}
+}
@@ -0,0 +1,69 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package config
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSaveAndLoadOptionsRoundTrip(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	path, err := DefaultConfigPath()
+	if err != nil {
+		t.Fatalf("unexpected error getting default config path: %s", err)
+	}
+	if want := filepath.Join(home, DefaultDirName, DefaultFileName); path != want {
+		t.Fatalf("got path %q, want %q", path, want)
+	}
+
+	want := IQConfig{
+		User:         "admin",
+		Token:        "admin123",
+		Server:       "http://localhost:8070",
+		Application:  "my-app",
+		Stage:        "build",
+		MaxRetries:   60,
+		PollInterval: 2 * time.Second,
+		Tool:         "nancy-client",
+		Version:      "1.0.0",
+	}
+
+	if err := SaveOptions(path, want); err != nil {
+		t.Fatalf("unexpected error saving options: %s", err)
+	}
+
+	got, err := LoadOptions(path)
+	if err != nil {
+		t.Fatalf("unexpected error loading options: %s", err)
+	}
+
+	if got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestLoadOptionsMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "does-not-exist")
+
+	if _, err := LoadOptions(path); err == nil {
+		t.Fatal("expected an error loading a missing config file, got nil")
+	}
+}
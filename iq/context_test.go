@@ -0,0 +1,133 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package iq
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// TestAuditWithSbomContextCancellationReturnsPromptly asserts that cancelling (or timing out) the
+// context passed to AuditWithSbomContext aborts a poll loop that would otherwise run until
+// MaxRetries polls a minute apart are used up, instead of blocking for the full configured
+// PollInterval.
+func TestAuditWithSbomContextCancellationReturnsPromptly(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/applications", func(w http.ResponseWriter, r *http.Request) {
+		publicID := r.URL.Query().Get("publicId")
+		_ = json.NewEncoder(w).Encode(applicationResponse{
+			Applications: []application{{ID: "internal-" + publicID}},
+		})
+	})
+	mux.HandleFunc("/api/v2/scan/applications/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		_ = json.NewEncoder(w).Encode(thirdPartyAPIResult{
+			StatusURL: "api/v2/polling/report-id",
+		})
+	})
+	mux.HandleFunc("/api/v2/polling/report-id", func(w http.ResponseWriter, r *http.Request) {
+		// never reports done, so without cancellation this poll loop only ends once MaxRetries is exceeded
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	logger := logrus.New()
+	logger.Out = io.Discard
+
+	server, err := New(logger, Options{
+		User:         "user",
+		Token:        "token",
+		Application:  "test-app",
+		Server:       ts.URL,
+		Stage:        "build",
+		MaxRetries:   1000,
+		PollInterval: time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error constructing server: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = server.AuditWithSbomContext(ctx, "<sbom/>")
+	elapsed := time.Since(start)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed > 5*time.Second {
+		t.Errorf("AuditWithSbomContext took %s to return after context timeout, want well under the configured PollInterval", elapsed)
+	}
+}
+
+// countingTransport wraps another RoundTripper, counting how many requests pass through it.
+type countingTransport struct {
+	wrapped http.RoundTripper
+	count   int32
+}
+
+func (c *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	atomic.AddInt32(&c.count, 1)
+	return c.wrapped.RoundTrip(req)
+}
+
+// TestAuditWithSbomUsesInjectedHTTPClient asserts that requests made during an audit actually
+// flow through the http.Client supplied via Options.HTTPClient.
+func TestAuditWithSbomUsesInjectedHTTPClient(t *testing.T) {
+	ts := newTestIQServer(t)
+	defer ts.Close()
+
+	logger := logrus.New()
+	logger.Out = io.Discard
+
+	transport := &countingTransport{wrapped: http.DefaultTransport}
+
+	server, err := New(logger, Options{
+		User:         "user",
+		Token:        "token",
+		Application:  "test-app",
+		Server:       ts.URL,
+		Stage:        "build",
+		MaxRetries:   3,
+		PollInterval: time.Millisecond,
+		HTTPClient:   &http.Client{Transport: transport},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error constructing server: %s", err)
+	}
+
+	if _, err := server.AuditWithSbom("<sbom/>"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if atomic.LoadInt32(&transport.count) == 0 {
+		t.Error("expected requests to flow through the injected HTTPClient, but it saw none")
+	}
+}
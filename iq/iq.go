@@ -19,18 +19,25 @@ package iq
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math"
+	"math/rand"
 	"net/http"
 	"net/url"
+	"os"
 	"reflect"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/sonatype-nexus-community/go-sona-types/cyclonedx"
+	"github.com/sonatype-nexus-community/go-sona-types/iq/config"
 	"github.com/sonatype-nexus-community/go-sona-types/ossindex"
 	"github.com/sonatype-nexus-community/go-sona-types/ossindex/types"
 	"github.com/sonatype-nexus-community/go-sona-types/useragent"
@@ -40,7 +47,52 @@ const internalApplicationIDURL = "/api/v2/applications?publicId="
 
 const thirdPartyAPILeft = "/api/v2/scan/applications/"
 
-const thirdPartyAPIRight = "/sources/nancy?stageId="
+const thirdPartyAPISourceCycloneDX = "nancy"
+
+const thirdPartyAPISourceSPDX = "spdx"
+
+const thirdPartyAPIRight = "/sources/%s?stageId=%s"
+
+// SBOMFormat identifies the shape of the SBOM document being submitted to Nexus IQ Server via
+// AuditWithSbomFormat, so the correct Content-Type header and Third Party API source can be used.
+type SBOMFormat int
+
+// Valid SBOMFormat values
+const (
+	// CycloneDXXML is a CycloneDX SBOM encoded as XML (the long-standing default for this package)
+	CycloneDXXML SBOMFormat = iota
+	// CycloneDXJSON is a CycloneDX SBOM encoded as JSON
+	CycloneDXJSON
+	// SPDXJSON is an SPDX SBOM encoded as JSON
+	SPDXJSON
+	// SPDXTagValue is an SPDX SBOM encoded using the SPDX tag:value format
+	SPDXTagValue
+)
+
+// contentType returns the HTTP Content-Type to submit an SBOM of this format with
+func (f SBOMFormat) contentType() string {
+	switch f {
+	case CycloneDXJSON:
+		return "application/vnd.cyclonedx+json"
+	case SPDXJSON:
+		return "application/spdx+json"
+	case SPDXTagValue:
+		return "text/spdx"
+	default:
+		return "application/xml"
+	}
+}
+
+// thirdPartyAPISource returns the Third Party API "source" path segment Nexus IQ Server expects
+// for this SBOM format
+func (f SBOMFormat) thirdPartyAPISource() string {
+	switch f {
+	case SPDXJSON, SPDXTagValue:
+		return thirdPartyAPISourceSPDX
+	default:
+		return thirdPartyAPISourceCycloneDX
+	}
+}
 
 // StatusURLResult is a struct to let the consumer know what the response from Nexus IQ Server was
 type StatusURLResult struct {
@@ -49,6 +101,36 @@ type StatusURLResult struct {
 	AbsoluteReportHTMLURL string `json:"-"`
 	IsError               bool   `json:"isError"`
 	ErrorMessage          string `json:"errorMessage"`
+	// Report is the detailed per-component policy evaluation, fetched from Nexus IQ Server's
+	// policy report endpoint after polling finishes successfully. Only populated when
+	// Options.FetchPolicyReport is set.
+	Report *PolicyReport `json:"-"`
+}
+
+// PolicyReport is the detailed, per-component policy evaluation for an audit, as fetched from
+// Nexus IQ Server's policy report endpoint
+type PolicyReport struct {
+	Components []ComponentViolation `json:"components"`
+}
+
+// ComponentViolation lists the policy violations found for a single component in a PolicyReport
+type ComponentViolation struct {
+	PackageURL string            `json:"packageUrl"`
+	Violations []PolicyViolation `json:"policyViolations"`
+}
+
+// PolicyViolation describes a single policy violated by a component
+type PolicyViolation struct {
+	PolicyName   string             `json:"policyName"`
+	ThreatLevel  int                `json:"threatLevel"`
+	Constraints  []PolicyConstraint `json:"constraints"`
+	WaivedStatus bool               `json:"waived"`
+}
+
+// PolicyConstraint is a single constraint (and the conditions that matched it) within a PolicyViolation
+type PolicyConstraint struct {
+	Name       string   `json:"constraintName"`
+	Conditions []string `json:"conditions"`
 }
 
 // Valid policy action values
@@ -71,13 +153,6 @@ type thirdPartyAPIResult struct {
 	StatusURL string `json:"statusUrl"`
 }
 
-var statusURLResp StatusURLResult
-
-type resultError struct {
-	finished bool
-	err      error
-}
-
 // ServerError is a custom error type that can be used to differentiate between
 // regular errors and errors specific to handling IQ Server
 type ServerError struct {
@@ -116,8 +191,11 @@ type Server struct {
 	// agent is a pointer to a *useragent.Agent struct, used for setting the User-Agent when communicating
 	// with IQ Server and OSS Index
 	agent *useragent.Agent
-	// tries is an internal variable for keeping track of how many times IQ Server has been polled
-	tries int
+	// httpClientOnce and httpClientCache lazily build and cache the http.Client derived from
+	// Options.TLSConfig, so a single connection pool is reused for the life of the Server instead
+	// of a fresh one being dialed on every request
+	httpClientOnce  sync.Once
+	httpClientCache *http.Client
 }
 
 // Options is a struct for setting options on the Server struct
@@ -146,8 +224,78 @@ type Options struct {
 	DBCacheName string
 	// TTL is the maximum time you want items to live in the DB Cache before being evicted (defaults to 12 hours)
 	TTL time.Time
-	// PollInterval is the time you want to wait between polls of IQ Server (defaults to 1 second)
+	// PollInterval is the fixed time you want to wait between polls of IQ Server (defaults to
+	// 1 second). Only honored when Backoff is left unset; set Backoff for exponential backoff
+	// between polls instead.
 	PollInterval time.Duration
+	// HTTPClient is the http.Client used to communicate with Nexus IQ Server. If nil, a client
+	// is constructed internally (honoring TLSConfig, if set). Set this if you need custom timeouts,
+	// proxy support, or other transport level settings.
+	HTTPClient *http.Client
+	// TLSConfig is used when constructing an internal http.Client (i.e. when HTTPClient is not set),
+	// to allow callers to supply custom CAs or other TLS settings.
+	TLSConfig *tls.Config
+	// SBOMSpecVersion is the CycloneDX spec version (ex: "1.3", "1.4", "1.5") to emit when
+	// AuditPackages builds a SBOM from OSS Index results. Defaults to cyclonedx's own default
+	// spec version when unset.
+	SBOMSpecVersion string
+	// Backoff controls the exponential backoff used between polls of IQ Server. If left as the
+	// zero value, PollInterval is honored as a fixed interval instead, for back-compat.
+	Backoff Backoff
+	// OnPollAttempt, if set, is called before each wait between polls of IQ Server, so callers
+	// can drive their own progress UI; polling itself writes no progress output to stdout.
+	OnPollAttempt func(attempt int, nextSleep time.Duration)
+	// FetchPolicyReport, if set, causes a follow-up request to be made once polling finishes
+	// successfully, to fetch the detailed per-component policy report and attach it to
+	// StatusURLResult.Report. Leave unset to avoid the extra round trip.
+	FetchPolicyReport bool
+}
+
+// Backoff configures the exponential backoff used between polls of IQ Server. Any field left as
+// its zero value falls back to the package default for that field: InitialInterval 1s,
+// MaxInterval 30s, Multiplier 2.0, Jitter 0.2.
+type Backoff struct {
+	// InitialInterval is the sleep duration used before the first retry
+	InitialInterval time.Duration
+	// MaxInterval caps how long a single sleep between polls can be
+	MaxInterval time.Duration
+	// Multiplier is applied to the previous interval to grow each successive sleep
+	Multiplier float64
+	// Jitter is the fraction (0-1) of randomness applied to each computed interval, to avoid
+	// many clients retrying in lockstep
+	Jitter float64
+}
+
+func (b Backoff) withDefaults() Backoff {
+	if b.InitialInterval == 0 {
+		b.InitialInterval = time.Second
+	}
+	if b.MaxInterval == 0 {
+		b.MaxInterval = 30 * time.Second
+	}
+	if b.Multiplier == 0 {
+		b.Multiplier = 2.0
+	}
+	if b.Jitter == 0 {
+		b.Jitter = 0.2
+	}
+	return b
+}
+
+// nextSleep computes how long to wait before the poll attempt numbered attempt (0-indexed),
+// applying the configured multiplier and a random amount of jitter in either direction.
+func (b Backoff) nextSleep(attempt int) time.Duration {
+	b = b.withDefaults()
+
+	interval := float64(b.InitialInterval) * math.Pow(b.Multiplier, float64(attempt))
+	if max := float64(b.MaxInterval); interval > max {
+		interval = max
+	}
+
+	delta := interval * b.Jitter
+	interval = interval - delta + rand.Float64()*2*delta
+
+	return time.Duration(interval)
 }
 
 // New is intended to be the way to obtain a iq instance, where you control the options
@@ -186,10 +334,72 @@ func New(logger *logrus.Logger, options Options) (server *Server, err error) {
 
 	ua := useragent.New(logger, useragent.Options{ClientTool: options.Tool, Version: options.Version})
 
-	server = &Server{logLady: logger, Options: options, tries: 0, agent: ua}
+	server = &Server{logLady: logger, Options: options, agent: ua}
 	return
 }
 
+// NewFromConfig is an alternative to New that first loads IQ Server settings saved via
+// iq/config.SaveOptions at path, and layers them in underneath whichever fields are already set
+// on overrides (so anything the caller explicitly sets takes priority over the file). This lets
+// downstream CLIs stop hand-rolling their own credential loading.
+func NewFromConfig(logger *logrus.Logger, path string, overrides Options) (server *Server, err error) {
+	fileOptions, err := config.LoadOptions(path)
+	if err != nil && !os.IsNotExist(err) {
+		return
+	}
+	err = nil
+
+	options := overrides
+	if options.User == "" {
+		options.User = fileOptions.User
+	}
+	if options.Token == "" {
+		options.Token = fileOptions.Token
+	}
+	if options.Server == "" {
+		options.Server = fileOptions.Server
+	}
+	if options.Application == "" {
+		options.Application = fileOptions.Application
+	}
+	if options.Stage == "" {
+		options.Stage = fileOptions.Stage
+	}
+	if options.MaxRetries == 0 {
+		options.MaxRetries = fileOptions.MaxRetries
+	}
+	if options.PollInterval == 0 {
+		options.PollInterval = fileOptions.PollInterval
+	}
+	if options.Tool == "" {
+		options.Tool = fileOptions.Tool
+	}
+	if options.Version == "" {
+		options.Version = fileOptions.Version
+	}
+
+	return New(logger, options)
+}
+
+// httpClient returns the http.Client to use for communicating with Nexus IQ Server, preferring
+// any client explicitly supplied via Options.HTTPClient, and falling back to one built from
+// Options.TLSConfig (or the zero-value http.Client if neither is set). The TLSConfig-derived
+// client is built once and cached on the Server, so polling reuses a single connection pool
+// instead of dialing (and TLS handshaking) fresh on every request.
+func (i *Server) httpClient() *http.Client {
+	if i.Options.HTTPClient != nil {
+		return i.Options.HTTPClient
+	}
+	i.httpClientOnce.Do(func() {
+		if i.Options.TLSConfig != nil {
+			i.httpClientCache = &http.Client{Transport: &http.Transport{TLSClientConfig: i.Options.TLSConfig}}
+		} else {
+			i.httpClientCache = &http.Client{}
+		}
+	})
+	return i.httpClientCache
+}
+
 func validateRequiredOption(options Options, optionName string) (err error) {
 	e := reflect.ValueOf(&options).Elem()
 	zero := e.FieldByName(optionName).IsZero()
@@ -200,10 +410,35 @@ func validateRequiredOption(options Options, optionName string) (err error) {
 }
 
 // AuditWithSbom accepts an sbom string, and will submit this to
-// Nexus IQ Server for audit, and return a struct of StatusURLResult
+// Nexus IQ Server for audit, and return a struct of StatusURLResult.
+// It is a thin wrapper around AuditWithSbomFormatContext, assuming a CycloneDX XML document,
+// using context.Background().
 func (i *Server) AuditWithSbom(sbom string) (StatusURLResult, error) {
+	return i.AuditWithSbomFormatContext(context.Background(), sbom, CycloneDXXML)
+}
+
+// AuditWithSbomContext is the context aware version of AuditWithSbom.
+// It is a thin wrapper around AuditWithSbomFormatContext, assuming a CycloneDX XML document.
+func (i *Server) AuditWithSbomContext(ctx context.Context, sbom string) (StatusURLResult, error) {
+	return i.AuditWithSbomFormatContext(ctx, sbom, CycloneDXXML)
+}
+
+// AuditWithSbomFormat accepts an sbom string and its SBOMFormat, and will submit this to Nexus
+// IQ Server for audit, and return a struct of StatusURLResult.
+// It is a thin wrapper around AuditWithSbomFormatContext using context.Background().
+func (i *Server) AuditWithSbomFormat(sbom string, format SBOMFormat) (StatusURLResult, error) {
+	return i.AuditWithSbomFormatContext(context.Background(), sbom, format)
+}
+
+// AuditWithSbomFormatContext is the context aware version of AuditWithSbomFormat. The supplied
+// context is threaded down through the HTTP requests made to Nexus IQ Server and the long poll
+// for results, allowing callers to cancel or time out an in-flight audit. format determines the
+// Content-Type header and Third Party API source used to submit sbom, allowing CycloneDX or
+// SPDX documents to be audited.
+func (i *Server) AuditWithSbomFormatContext(ctx context.Context, sbom string, format SBOMFormat) (StatusURLResult, error) {
 	i.logLady.WithFields(logrus.Fields{
 		"sbom":           sbom,
+		"format":         format,
 		"application_id": i.Options.Application,
 	}).Info("Beginning audit with IQ using provided SBOM")
 
@@ -212,18 +447,26 @@ func (i *Server) AuditWithSbom(sbom string) (StatusURLResult, error) {
 		warnUserOfBadLifeChoices()
 	}
 
-	internalID, err := i.getInternalApplicationID(i.Options.Application)
+	internalID, err := i.getInternalApplicationID(ctx, i.Options.Application)
 	if internalID == "" && err != nil {
 		i.logLady.Error("Internal ID not obtained from Nexus IQ")
-		return statusURLResp, err
+		return StatusURLResult{}, err
 	}
 
-	return i.audit(sbom, internalID)
+	return i.audit(ctx, sbom, internalID, format)
 }
 
 // AuditPackages accepts a slice of purls, and configuration, and will submit these to
-// Nexus IQ Server for audit, and return a struct of StatusURLResult
+// Nexus IQ Server for audit, and return a struct of StatusURLResult.
+// It is a thin wrapper around AuditPackagesContext using context.Background().
 func (i *Server) AuditPackages(purls []string) (StatusURLResult, error) {
+	return i.AuditPackagesContext(context.Background(), purls)
+}
+
+// AuditPackagesContext is the context aware version of AuditPackages. The supplied context is
+// threaded down through the HTTP requests made to OSS Index and Nexus IQ Server, as well as the
+// long poll for results, allowing callers to cancel or time out an in-flight audit.
+func (i *Server) AuditPackagesContext(ctx context.Context, purls []string) (StatusURLResult, error) {
 	i.logLady.WithFields(logrus.Fields{
 		"purls":          purls,
 		"application_id": i.Options.Application,
@@ -234,10 +477,10 @@ func (i *Server) AuditPackages(purls []string) (StatusURLResult, error) {
 		warnUserOfBadLifeChoices()
 	}
 
-	internalID, err := i.getInternalApplicationID(i.Options.Application)
+	internalID, err := i.getInternalApplicationID(ctx, i.Options.Application)
 	if internalID == "" && err != nil {
 		i.logLady.Error("Internal ID not obtained from Nexus IQ")
-		return statusURLResp, err
+		return StatusURLResult{}, err
 	}
 
 	ossIndexOptions := types.Options{
@@ -251,69 +494,112 @@ func (i *Server) AuditPackages(purls []string) (StatusURLResult, error) {
 
 	resultsFromOssIndex, err := ossi.AuditPackages(purls)
 	if err != nil {
-		return statusURLResp, &ServerError{
+		return StatusURLResult{}, &ServerError{
 			Err:     err,
 			Message: "There was an issue auditing packages using OSS Index",
 		}
 	}
 
-	dx := cyclonedx.Default(i.logLady)
-
-	sbom := dx.FromCoordinates(resultsFromOssIndex)
+	var sbom string
+	if i.Options.SBOMSpecVersion != "" {
+		dx := cyclonedx.New(i.logLady, cyclonedx.Options{SpecVersion: i.Options.SBOMSpecVersion})
+		sbom = dx.FromCoordinates(resultsFromOssIndex)
+	} else {
+		dx := cyclonedx.Default(i.logLady)
+		sbom = dx.FromCoordinates(resultsFromOssIndex)
+	}
 	i.logLady.WithField("sbom", sbom).Debug("Obtained cyclonedx SBOM")
 
-	return i.audit(sbom, internalID)
+	return i.audit(ctx, sbom, internalID, CycloneDXXML)
 }
 
-func (i *Server) audit(sbom string, internalID string) (StatusURLResult, error) {
+// pollOutcome carries the result of a single audit's poll loop back to the caller of audit.
+// It is scoped to a single audit call, never shared across goroutines or calls.
+type pollOutcome struct {
+	result StatusURLResult
+	err    error
+}
+
+func (i *Server) audit(ctx context.Context, sbom string, internalID string, format SBOMFormat) (StatusURLResult, error) {
 	i.logLady.WithFields(logrus.Fields{
 		"internal_id": internalID,
 		"sbom":        sbom,
+		"format":      format,
 	}).Debug("Submitting to Third Party API")
-	statusURL, err := i.submitToThirdPartyAPI(sbom, internalID)
+	statusURL, err := i.submitToThirdPartyAPI(ctx, sbom, internalID, format)
 	if err != nil {
-		return statusURLResp, &ServerError{
+		return StatusURLResult{}, &ServerError{
 			Err:     err,
 			Message: "There was an issue submitting to the Third Party API",
 		}
 	}
 	if statusURL == "" {
 		i.logLady.Error("StatusURL not obtained from Third Party API")
-		return statusURLResp, &ServerError{
+		return StatusURLResult{}, &ServerError{
 			Err:     fmt.Errorf("There was an issue submitting your sbom to the Nexus IQ Third Party API, sbom: %s", sbom),
 			Message: "There was an issue obtaining a StatusURL",
 		}
 	}
 
-	statusURLResp = StatusURLResult{}
-
-	finishedChan := make(chan resultError)
+	outcomeChan := make(chan pollOutcome, 1)
 
 	go func() {
-		defer close(finishedChan)
+		fullStatusURL := fmt.Sprintf("%s/%s", i.Options.Server, statusURL)
+		tries := 0
 		for {
 			select {
-			case <-finishedChan:
+			case <-ctx.Done():
+				outcomeChan <- pollOutcome{err: ctx.Err()}
 				return
 			default:
-				if errPoll := i.pollIQServer(fmt.Sprintf("%s/%s", i.Options.Server, statusURL), finishedChan); errPoll != nil {
-					finishedChan <- resultError{finished: true, err: errPoll}
-					return
+			}
+
+			attempt := tries
+			result, done, errPoll := i.pollIQServer(ctx, fullStatusURL, &tries)
+			if errPoll != nil {
+				outcomeChan <- pollOutcome{err: errPoll}
+				return
+			}
+			if done {
+				if i.Options.FetchPolicyReport && !result.IsError {
+					report, errReport := i.fetchPolicyReport(ctx, internalID, reportIDFromReportURL(result.ReportHTMLURL))
+					if errReport != nil {
+						i.logLady.WithField("err", errReport).Warn("Unable to fetch policy report from Nexus IQ Server")
+					} else {
+						result.Report = report
+					}
 				}
-				i.logLady.Trace("waiting to poll Nexus IQ")
-				time.Sleep(i.Options.PollInterval)
+				outcomeChan <- pollOutcome{result: result}
+				return
+			}
+
+			sleepFor := i.Options.PollInterval
+			if i.Options.Backoff != (Backoff{}) {
+				sleepFor = i.Options.Backoff.nextSleep(attempt)
+			}
+			if i.Options.OnPollAttempt != nil {
+				i.Options.OnPollAttempt(attempt, sleepFor)
+			}
+
+			i.logLady.WithField("sleep", sleepFor).Trace("waiting to poll Nexus IQ")
+			select {
+			case <-ctx.Done():
+				outcomeChan <- pollOutcome{err: ctx.Err()}
+				return
+			case <-time.After(sleepFor):
 			}
 		}
 	}()
 
-	r := <-finishedChan
-	return statusURLResp, r.err
+	o := <-outcomeChan
+	return o.result, o.err
 }
 
-func (i *Server) getInternalApplicationID(applicationID string) (string, error) {
-	client := &http.Client{}
+func (i *Server) getInternalApplicationID(ctx context.Context, applicationID string) (string, error) {
+	client := i.httpClient()
 
-	req, err := http.NewRequest(
+	req, err := http.NewRequestWithContext(
+		ctx,
 		"GET",
 		fmt.Sprintf("%s%s%s", i.Options.Server, internalApplicationIDURL, applicationID),
 		nil,
@@ -402,14 +688,16 @@ func (i *Server) getInternalApplicationID(applicationID string) (string, error)
 	}
 }
 
-func (i *Server) submitToThirdPartyAPI(sbom string, internalID string) (string, error) {
+func (i *Server) submitToThirdPartyAPI(ctx context.Context, sbom string, internalID string, format SBOMFormat) (string, error) {
 	i.logLady.Debug("Beginning to submit to Third Party API")
-	client := &http.Client{}
+	client := i.httpClient()
 
-	url := fmt.Sprintf("%s%s", i.Options.Server, fmt.Sprintf("%s%s%s%s", thirdPartyAPILeft, internalID, thirdPartyAPIRight, i.Options.Stage))
+	url := fmt.Sprintf("%s%s%s", i.Options.Server, thirdPartyAPILeft, internalID) +
+		fmt.Sprintf(thirdPartyAPIRight, format.thirdPartyAPISource(), i.Options.Stage)
 	i.logLady.WithField("url", url).Debug("Crafted URL for submission to Third Party API")
 
-	req, err := http.NewRequest(
+	req, err := http.NewRequestWithContext(
+		ctx,
 		"POST",
 		url,
 		bytes.NewBuffer([]byte(sbom)),
@@ -423,7 +711,7 @@ func (i *Server) submitToThirdPartyAPI(sbom string, internalID string) (string,
 
 	req.SetBasicAuth(i.Options.User, i.Options.Token)
 	req.Header.Set("User-Agent", i.agent.GetUserAgent())
-	req.Header.Set("Content-Type", "application/xml")
+	req.Header.Set("Content-Type", format.contentType())
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -474,23 +762,27 @@ func (i *Server) submitToThirdPartyAPI(sbom string, internalID string) (string,
 	}
 }
 
-func (i *Server) pollIQServer(statusURL string, finished chan resultError) error {
+// pollIQServer makes a single poll request against statusURL. tries points at the caller's
+// per-audit attempt counter, which pollIQServer reads and increments; it is never shared across
+// concurrent audits. It returns the decoded result and done=true once Nexus IQ Server has a
+// final answer (including when the response itself reports IsError), or a non-nil error if the
+// poll could not be completed at all.
+func (i *Server) pollIQServer(ctx context.Context, statusURL string, tries *int) (StatusURLResult, bool, error) {
 	i.logLady.WithFields(logrus.Fields{
-		"attempt_number": i.tries,
+		"attempt_number": *tries,
 		"max_retries":    i.Options.MaxRetries,
 		"status_url":     statusURL,
 	}).Trace("Polling Nexus IQ for response")
-	if i.tries > i.Options.MaxRetries {
+	if *tries > i.Options.MaxRetries {
 		i.logLady.WithField("retries", i.Options.MaxRetries).Error("Maximum tries exceeded, finished polling, consider bumping up Max Retries")
 		err := fmt.Errorf("exceeded max retries: %d", i.Options.MaxRetries)
-		finished <- resultError{finished: true, err: err}
-		return &ServerError{Err: err, Message: "exceeded max retries"}
+		return StatusURLResult{}, false, &ServerError{Err: err, Message: "exceeded max retries"}
 	}
 
-	client := &http.Client{}
-	req, err := http.NewRequest("GET", statusURL, nil)
+	client := i.httpClient()
+	req, err := http.NewRequestWithContext(ctx, "GET", statusURL, nil)
 	if err != nil {
-		return &ServerError{
+		return StatusURLResult{}, false, &ServerError{
 			Err:     err,
 			Message: "Could not poll IQ server",
 		}
@@ -503,8 +795,7 @@ func (i *Server) pollIQServer(statusURL string, finished chan resultError) error
 	resp, err := client.Do(req)
 
 	if err != nil {
-		finished <- resultError{finished: true, err: err}
-		return &ServerError{
+		return StatusURLResult{}, false, &ServerError{
 			Err:     err,
 			Message: "There was an error polling Nexus IQ Server",
 		}
@@ -520,7 +811,7 @@ func (i *Server) pollIQServer(statusURL string, finished chan resultError) error
 	if resp.StatusCode == http.StatusOK {
 		bodyBytes, err := ioutil.ReadAll(resp.Body)
 		if err != nil {
-			return &ServerError{
+			return StatusURLResult{}, false, &ServerError{
 				Err:     err,
 				Message: "There was an error with processing the response from polling Nexus IQ Server",
 			}
@@ -529,7 +820,7 @@ func (i *Server) pollIQServer(statusURL string, finished chan resultError) error
 		var response StatusURLResult
 		err = json.Unmarshal(bodyBytes, &response)
 		if err != nil {
-			return &ServerError{
+			return StatusURLResult{}, false, &ServerError{
 				Err:     err,
 				Message: "Could not unmarshal response from IQ server",
 			}
@@ -539,26 +830,85 @@ func (i *Server) pollIQServer(statusURL string, finished chan resultError) error
 			"response": response,
 		}).Trace("Nexus IQ polling response")
 
-		statusURLResp = response
-		if response.IsError {
-			finished <- resultError{finished: true, err: nil}
+		response.populateAbsoluteURL(i.Options.Server)
+		return response, true, nil
+	}
+
+	*tries++
+	return StatusURLResult{}, false, nil
+}
+
+// reportIDFromReportURL extracts the report ID Nexus IQ Server uses to key a policy report from
+// the last path segment of a report HTML URL (ex: ".../report/abcd1234" -> "abcd1234")
+func reportIDFromReportURL(reportHTMLURL string) string {
+	parsedReportURL, err := url.Parse(reportHTMLURL)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimRight(parsedReportURL.Path[strings.LastIndex(parsedReportURL.Path, "/")+1:], "/")
+}
+
+// fetchPolicyReport retrieves the detailed per-component policy report for internalID/reportID
+// from Nexus IQ Server's policy report endpoint
+func (i *Server) fetchPolicyReport(ctx context.Context, internalID string, reportID string) (*PolicyReport, error) {
+	client := i.httpClient()
+
+	url := fmt.Sprintf("%s/api/v2/applications/%s/reports/%s/policy", i.Options.Server, internalID, reportID)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, &ServerError{
+			Err:     err,
+			Message: "Could not request the Nexus IQ Server policy report",
 		}
+	}
 
-		statusURLResp.populateAbsoluteURL(i.Options.Server)
-		finished <- resultError{finished: true, err: nil}
+	req.SetBasicAuth(i.Options.User, i.Options.Token)
+	req.Header.Set("User-Agent", i.agent.GetUserAgent())
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, &ServerError{
+			Err:     err,
+			Message: "There was an error fetching the policy report from Nexus IQ Server",
+		}
 	}
-	i.tries++
-	fmt.Print(".")
-	return err
+	//noinspection GoUnhandledErrorResult
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &ServerError{
+			Err:     fmt.Errorf("unable to fetch policy report, status code: %d, status: %s", resp.StatusCode, resp.Status),
+			Message: "Unable to fetch policy report from Nexus IQ Server",
+		}
+	}
+
+	bodyBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &ServerError{
+			Err:     err,
+			Message: "There was an error reading the policy report response from Nexus IQ Server",
+		}
+	}
+
+	var report PolicyReport
+	if err = json.Unmarshal(bodyBytes, &report); err != nil {
+		return nil, &ServerError{
+			Err:     err,
+			Message: "Could not unmarshal policy report from Nexus IQ Server",
+		}
+	}
+
+	return &report, nil
 }
 
 func (i *StatusURLResult) populateAbsoluteURL(iqServerBaseURL string) {
-	parsedReportURL, _ := url.Parse(statusURLResp.ReportHTMLURL)
+	parsedReportURL, _ := url.Parse(i.ReportHTMLURL)
 	if parsedReportURL.IsAbs() {
-		statusURLResp.AbsoluteReportHTMLURL = parsedReportURL.String()
+		i.AbsoluteReportHTMLURL = parsedReportURL.String()
 		return
 	}
-	statusURLResp.AbsoluteReportHTMLURL =
+	i.AbsoluteReportHTMLURL =
 		strings.TrimRight(iqServerBaseURL, "/") +
 			"/" +
 			strings.TrimLeft(parsedReportURL.Path, "/")
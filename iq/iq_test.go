@@ -0,0 +1,125 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package iq
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// newTestIQServer spins up a fake Nexus IQ Server that immediately resolves applications and
+// third party scans, and answers every poll with a distinct, deterministic PolicyAction so
+// concurrent callers can assert they each got their own result back.
+func newTestIQServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	var mu sync.Mutex
+	statusByApp := make(map[string]string)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/applications", func(w http.ResponseWriter, r *http.Request) {
+		publicID := r.URL.Query().Get("publicId")
+		_ = json.NewEncoder(w).Encode(applicationResponse{
+			Applications: []application{{ID: "internal-" + publicID}},
+		})
+	})
+	mux.HandleFunc("/api/v2/scan/applications/", func(w http.ResponseWriter, r *http.Request) {
+		internalID := strings.TrimPrefix(r.URL.Path, "/api/v2/scan/applications/")
+		internalID = strings.SplitN(internalID, "/", 2)[0]
+
+		mu.Lock()
+		statusByApp[internalID] = internalID
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusAccepted)
+		_ = json.NewEncoder(w).Encode(thirdPartyAPIResult{
+			StatusURL: fmt.Sprintf("api/v2/polling/%s", internalID),
+		})
+	})
+	mux.HandleFunc("/api/v2/polling/", func(w http.ResponseWriter, r *http.Request) {
+		internalID := strings.TrimPrefix(r.URL.Path, "/api/v2/polling/")
+		_ = json.NewEncoder(w).Encode(StatusURLResult{
+			PolicyAction:  internalID,
+			ReportHTMLURL: fmt.Sprintf("ui/report/%s", internalID),
+		})
+	})
+
+	return httptest.NewServer(mux)
+}
+
+// TestAuditPackagesConcurrent exercises several AuditPackages calls concurrently, each on its own
+// Server instance but all against the same fake Nexus IQ Server, and asserts each call gets back
+// the result for its own application, verifying there is no cross-contamination through shared
+// poll state. It drives the public AuditPackages entry point (rather than the unexported audit
+// helper) so that getInternalApplicationID and the ossindex step are exercised too. Each call passes no purls, since the
+// ossindex package currently offers no way to point it at a fake server from here, but an empty
+// purl list still runs the full AuditPackages -> cyclonedx -> audit -> poll path for each app.
+func TestAuditPackagesConcurrent(t *testing.T) {
+	ts := newTestIQServer(t)
+	defer ts.Close()
+
+	logger := logrus.New()
+	logger.Out = io.Discard
+
+	const appCount = 10
+	var wg sync.WaitGroup
+	errs := make([]error, appCount)
+	results := make([]StatusURLResult, appCount)
+
+	for n := 0; n < appCount; n++ {
+		appID := fmt.Sprintf("app-%d", n)
+		server, err := New(logger, Options{
+			User:         "user",
+			Token:        "token",
+			Application:  appID,
+			Server:       ts.URL,
+			Stage:        "build",
+			MaxRetries:   3,
+			PollInterval: time.Millisecond,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error constructing server: %s", err)
+		}
+
+		wg.Add(1)
+		go func(i int, server *Server) {
+			defer wg.Done()
+			results[i], errs[i] = server.AuditPackages(nil)
+		}(n, server)
+	}
+
+	wg.Wait()
+
+	for n := 0; n < appCount; n++ {
+		if errs[n] != nil {
+			t.Fatalf("app-%d: unexpected error: %s", n, errs[n])
+		}
+		want := "internal-app-" + fmt.Sprint(n)
+		if results[n].PolicyAction != want {
+			t.Errorf("app-%d: got policy action %q, want %q", n, results[n].PolicyAction, want)
+		}
+	}
+}
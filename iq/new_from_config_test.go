@@ -0,0 +1,106 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package iq
+
+import (
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sonatype-nexus-community/go-sona-types/iq/config"
+)
+
+// TestNewFromConfigMergesOverridesOverFile asserts that fields explicitly set on the overrides
+// Options passed to NewFromConfig win, and that everything else falls back to what was persisted
+// to the config file.
+func TestNewFromConfigMergesOverridesOverFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+
+	if err := config.SaveOptions(path, config.IQConfig{
+		User:         "file-user",
+		Token:        "file-token",
+		Server:       "http://file-server:8070",
+		Application:  "file-app",
+		Stage:        "build",
+		MaxRetries:   42,
+		PollInterval: 5 * time.Second,
+		Tool:         "file-tool",
+		Version:      "9.9.9",
+	}); err != nil {
+		t.Fatalf("unexpected error saving config: %s", err)
+	}
+
+	logger := logrus.New()
+	logger.Out = io.Discard
+
+	server, err := NewFromConfig(logger, path, Options{
+		Application: "override-app",
+		Stage:       "release",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// explicitly overridden fields win over the file
+	if server.Options.Application != "override-app" {
+		t.Errorf("got Application %q, want override value %q", server.Options.Application, "override-app")
+	}
+	if server.Options.Stage != "release" {
+		t.Errorf("got Stage %q, want override value %q", server.Options.Stage, "release")
+	}
+
+	// everything else falls back to the file
+	if server.Options.User != "file-user" {
+		t.Errorf("got User %q, want file value %q", server.Options.User, "file-user")
+	}
+	if server.Options.Token != "file-token" {
+		t.Errorf("got Token %q, want file value %q", server.Options.Token, "file-token")
+	}
+	if server.Options.Server != "http://file-server:8070" {
+		t.Errorf("got Server %q, want file value %q", server.Options.Server, "http://file-server:8070")
+	}
+	if server.Options.MaxRetries != 42 {
+		t.Errorf("got MaxRetries %d, want file value %d", server.Options.MaxRetries, 42)
+	}
+	if server.Options.Tool != "file-tool" {
+		t.Errorf("got Tool %q, want file value %q", server.Options.Tool, "file-tool")
+	}
+}
+
+// TestNewFromConfigMissingFile asserts that NewFromConfig still succeeds, honoring only the
+// provided overrides, when no config file exists yet at path.
+func TestNewFromConfigMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "does-not-exist")
+
+	logger := logrus.New()
+	logger.Out = io.Discard
+
+	server, err := NewFromConfig(logger, path, Options{
+		User:        "user",
+		Token:       "token",
+		Server:      "http://localhost:8070",
+		Application: "my-app",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if server.Options.Application != "my-app" {
+		t.Errorf("got Application %q, want %q", server.Options.Application, "my-app")
+	}
+}
@@ -0,0 +1,188 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package iq
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// newTestIQServerWithPolicyReport spins up a fake Nexus IQ Server for a single application whose
+// poll always succeeds, with policyHandler serving the follow-up policy report request. It
+// returns the server along with a counter of how many times policyHandler was invoked.
+func newTestIQServerWithPolicyReport(t *testing.T, policyHandler http.HandlerFunc) (*httptest.Server, *int32) {
+	t.Helper()
+
+	var policyRequests int32
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/applications", func(w http.ResponseWriter, r *http.Request) {
+		publicID := r.URL.Query().Get("publicId")
+		_ = json.NewEncoder(w).Encode(applicationResponse{
+			Applications: []application{{ID: "internal-" + publicID}},
+		})
+	})
+	mux.HandleFunc("/api/v2/scan/applications/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		_ = json.NewEncoder(w).Encode(thirdPartyAPIResult{
+			StatusURL: "api/v2/polling/report-id",
+		})
+	})
+	mux.HandleFunc("/api/v2/polling/report-id", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(StatusURLResult{
+			PolicyAction:  PolicyActionNone,
+			ReportHTMLURL: "ui/report/report-id",
+		})
+	})
+	mux.HandleFunc("/api/v2/applications/internal-test-app/reports/report-id/policy", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&policyRequests, 1)
+		policyHandler(w, r)
+	})
+
+	return httptest.NewServer(mux), &policyRequests
+}
+
+func TestAuditWithSbomFetchesPolicyReportWhenEnabled(t *testing.T) {
+	ts, policyRequests := newTestIQServerWithPolicyReport(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(PolicyReport{
+			Components: []ComponentViolation{
+				{
+					PackageURL: "pkg:golang/example@1.0.0",
+					Violations: []PolicyViolation{
+						{
+							PolicyName:   "Security-High",
+							ThreatLevel:  8,
+							WaivedStatus: false,
+							Constraints: []PolicyConstraint{
+								{Name: "CVSS >= 7", Conditions: []string{"CVSS score >= 7"}},
+							},
+						},
+					},
+				},
+			},
+		})
+	})
+	defer ts.Close()
+
+	logger := logrus.New()
+	logger.Out = io.Discard
+
+	server, err := New(logger, Options{
+		User:              "user",
+		Token:             "token",
+		Application:       "test-app",
+		Server:            ts.URL,
+		Stage:             "build",
+		MaxRetries:        3,
+		PollInterval:      time.Millisecond,
+		FetchPolicyReport: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error constructing server: %s", err)
+	}
+
+	result, err := server.AuditWithSbom("<sbom/>")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if result.Report == nil {
+		t.Fatal("expected a policy report to be attached, got nil")
+	}
+	if len(result.Report.Components) != 1 || result.Report.Components[0].PackageURL != "pkg:golang/example@1.0.0" {
+		t.Errorf("got report %+v, want one component for pkg:golang/example@1.0.0", result.Report)
+	}
+	if got := atomic.LoadInt32(policyRequests); got != 1 {
+		t.Errorf("got %d policy report requests, want 1", got)
+	}
+}
+
+func TestAuditWithSbomSkipsPolicyReportWhenDisabled(t *testing.T) {
+	ts, policyRequests := newTestIQServerWithPolicyReport(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("policy report endpoint should not have been called")
+	})
+	defer ts.Close()
+
+	logger := logrus.New()
+	logger.Out = io.Discard
+
+	server, err := New(logger, Options{
+		User:         "user",
+		Token:        "token",
+		Application:  "test-app",
+		Server:       ts.URL,
+		Stage:        "build",
+		MaxRetries:   3,
+		PollInterval: time.Millisecond,
+		// FetchPolicyReport left unset (false)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error constructing server: %s", err)
+	}
+
+	result, err := server.AuditWithSbom("<sbom/>")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if result.Report != nil {
+		t.Errorf("expected no policy report to be attached, got %+v", result.Report)
+	}
+	if got := atomic.LoadInt32(policyRequests); got != 0 {
+		t.Errorf("got %d policy report requests, want 0", got)
+	}
+}
+
+func TestAuditWithSbomPolicyReportFetchFailureIsNonFatal(t *testing.T) {
+	ts, _ := newTestIQServerWithPolicyReport(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("not json"))
+	})
+	defer ts.Close()
+
+	logger := logrus.New()
+	logger.Out = io.Discard
+
+	server, err := New(logger, Options{
+		User:              "user",
+		Token:             "token",
+		Application:       "test-app",
+		Server:            ts.URL,
+		Stage:             "build",
+		MaxRetries:        3,
+		PollInterval:      time.Millisecond,
+		FetchPolicyReport: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error constructing server: %s", err)
+	}
+
+	result, err := server.AuditWithSbom("<sbom/>")
+	if err != nil {
+		t.Fatalf("expected the audit to still succeed when the policy report fetch fails, got error: %s", err)
+	}
+	if result.Report != nil {
+		t.Errorf("expected no policy report to be attached after a failed fetch, got %+v", result.Report)
+	}
+}
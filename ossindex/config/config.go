@@ -0,0 +1,72 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package config persists and loads OSS Index credentials to/from a YAML file on disk, so
+// consumers of the ossindex package don't each need to hand-roll their own credential storage.
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+)
+
+// DefaultDirName is the directory (relative to the user's home directory) config is stored in by default
+const DefaultDirName = ".ossindex"
+
+// DefaultFileName is the file config is stored in by default, within DefaultDirName
+const DefaultFileName = ".oss-index-config"
+
+// OSSIndexConfig is the on-disk representation of the settings needed to talk to OSS Index.
+// It mirrors the authentication fields of ossindex/types.Options.
+type OSSIndexConfig struct {
+	Username    string `yaml:"username"`
+	Token       string `yaml:"token"`
+	DBCacheName string `yaml:"dbcachename"`
+}
+
+// DefaultConfigPath returns $HOME/.ossindex/.oss-index-config, the conventional location for OSSIndexConfig
+func DefaultConfigPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, DefaultDirName, DefaultFileName), nil
+}
+
+// LoadOptions reads and unmarshals the YAML encoded OSSIndexConfig found at path
+func LoadOptions(path string) (config OSSIndexConfig, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	err = yaml.Unmarshal(data, &config)
+	return
+}
+
+// SaveOptions marshals config to YAML and writes it to path, creating any missing parent
+// directories along the way
+func SaveOptions(path string, config OSSIndexConfig) (err error) {
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return
+	}
+	return os.WriteFile(path, data, 0o600)
+}